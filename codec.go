@@ -0,0 +1,47 @@
+package gocache
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"io"
+)
+
+// Codec encodes and decodes a Cache's entries, allowing SaveToWriter/ReadFromReader (and, by
+// extension, SaveToFile/ReadFromFile) to support more than one persistence format.
+type Codec interface {
+	// Encode writes entries to w
+	Encode(w io.Writer, entries map[string]*Entry) error
+
+	// Decode reads entries previously written by Encode from r
+	Decode(r io.Reader, entries *map[string]*Entry) error
+}
+
+// GobCodec encodes entries using encoding/gob. It's the default Codec, and the one SaveToFile and
+// ReadFromFile have always used.
+type GobCodec struct{}
+
+// Encode writes entries to w using encoding/gob
+func (GobCodec) Encode(w io.Writer, entries map[string]*Entry) error {
+	return gob.NewEncoder(w).Encode(entries)
+}
+
+// Decode reads entries from r using encoding/gob
+func (GobCodec) Decode(r io.Reader, entries *map[string]*Entry) error {
+	return gob.NewDecoder(r).Decode(entries)
+}
+
+// JSONCodec encodes entries using encoding/json
+//
+// This is more portable and human-readable than GobCodec, at the cost of a larger output size and
+// slower encoding/decoding.
+type JSONCodec struct{}
+
+// Encode writes entries to w using encoding/json
+func (JSONCodec) Encode(w io.Writer, entries map[string]*Entry) error {
+	return json.NewEncoder(w).Encode(entries)
+}
+
+// Decode reads entries from r using encoding/json
+func (JSONCodec) Decode(r io.Reader, entries *map[string]*Entry) error {
+	return json.NewDecoder(r).Decode(entries)
+}
@@ -0,0 +1,25 @@
+//go:build msgpack
+
+package gocache
+
+import (
+	"io"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// MessagePackCodec encodes entries using MessagePack.
+//
+// It's more compact than JSONCodec while remaining a cross-language format, at the cost of an extra
+// dependency. Build with the "msgpack" build tag to include it, e.g. `go build -tags msgpack`.
+type MessagePackCodec struct{}
+
+// Encode writes entries to w using MessagePack
+func (MessagePackCodec) Encode(w io.Writer, entries map[string]*Entry) error {
+	return msgpack.NewEncoder(w).Encode(entries)
+}
+
+// Decode reads entries from r using MessagePack
+func (MessagePackCodec) Decode(r io.Reader, entries *map[string]*Entry) error {
+	return msgpack.NewDecoder(r).Decode(entries)
+}
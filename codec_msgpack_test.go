@@ -0,0 +1,28 @@
+//go:build msgpack
+
+package gocache
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCache_SaveToWriterAndReadFromReaderWithMessagePackCodec(t *testing.T) {
+	cache := NewCache()
+	cache.Set("key1", "value1")
+	cache.Set("key2", "value2")
+	var buffer bytes.Buffer
+	if err := cache.SaveToWriter(&buffer, MessagePackCodec{}); err != nil {
+		t.Fatal("shouldn't have returned an error, but got:", err.Error())
+	}
+	newCache := NewCache()
+	if _, err := newCache.ReadFromReader(&buffer, MessagePackCodec{}); err != nil {
+		t.Fatal("shouldn't have returned an error, but got:", err.Error())
+	}
+	if newCache.Count() != 2 {
+		t.Error("expected newCache to have 2 entries, but got", newCache.Count())
+	}
+	if value, _ := newCache.Get("key1"); value != "value1" {
+		t.Errorf("expected key1 to have value 'value1', but got '%v'", value)
+	}
+}
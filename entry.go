@@ -0,0 +1,71 @@
+package gocache
+
+import (
+	"time"
+	"unsafe"
+)
+
+// Entry is a cache entry
+type Entry struct {
+	// Key is the key by which the entry can be retrieved
+	Key string
+
+	// Value is the value associated to the Key
+	Value interface{}
+
+	// RelevantTimestamp is the timestamp used to determine whether the entry is relevant or not.
+	// This value is updated when the entry is created, and, if the eviction policy is LeastRecentlyUsed,
+	// every time the entry is accessed.
+	RelevantTimestamp time.Time
+
+	// Expiration is the unix nanosecond timestamp at which the entry expires, or NoExpiration if the
+	// entry should never expire
+	Expiration int64
+
+	previous *Entry
+	next     *Entry
+
+	// visited is used by the SIEVE eviction policy to know whether the entry has been accessed
+	// since the last time the SIEVE hand passed over it
+	visited bool
+}
+
+// Accessed updates the RelevantTimestamp of the entry to now
+func (entry *Entry) Accessed() {
+	entry.RelevantTimestamp = time.Now()
+}
+
+// Expired returns whether the entry has expired
+func (entry *Entry) Expired() bool {
+	if entry.Expiration > 0 {
+		return time.Now().UnixNano() > entry.Expiration
+	}
+	return false
+}
+
+// Previous returns the entry before this one in the cache's list (towards the tail), or nil.
+// This, along with SetPrevious, Next and SetNext, exists so that entrylist.MoveToHead/RemoveReferences
+// can relink entries without needing direct access to the unexported previous/next fields.
+func (entry *Entry) Previous() *Entry {
+	return entry.previous
+}
+
+// SetPrevious sets the entry before this one in the cache's list
+func (entry *Entry) SetPrevious(previous *Entry) {
+	entry.previous = previous
+}
+
+// Next returns the entry after this one in the cache's list (towards the head), or nil
+func (entry *Entry) Next() *Entry {
+	return entry.next
+}
+
+// SetNext sets the entry after this one in the cache's list
+func (entry *Entry) SetNext(next *Entry) {
+	entry.next = next
+}
+
+// SizeInBytes returns an approximation of the size of the entry in bytes
+func (entry *Entry) SizeInBytes() int {
+	return int(unsafe.Sizeof(entry.Expiration)) + len(entry.Key) + int(unsafe.Sizeof(entry.Value)) + int(unsafe.Sizeof(entry.RelevantTimestamp))
+}
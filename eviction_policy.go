@@ -0,0 +1,21 @@
+package gocache
+
+// EvictionPolicy is the eviction policy of a Cache
+type EvictionPolicy int
+
+const (
+	// FirstInFirstOut means that the cache will evict the oldest entry first, regardless of whether
+	// it has been accessed recently or not
+	FirstInFirstOut EvictionPolicy = iota
+
+	// LeastRecentlyUsed means that the cache will evict the least recently used entry first
+	LeastRecentlyUsed
+
+	// SIEVE is a lazy-eviction policy that, unlike LeastRecentlyUsed, does not move entries on access.
+	// Instead, each Entry carries a single visited bit that Get sets, and eviction is done with a "hand"
+	// pointer that walks the list from tail to head: entries whose visited bit is set are given a second
+	// chance (the bit is cleared and the hand advances), while the first unvisited entry it encounters is
+	// evicted. This avoids the write-lock-held list relinking that LeastRecentlyUsed does on every read,
+	// while still outperforming FirstInFirstOut on most workloads.
+	SIEVE
+)
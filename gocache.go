@@ -4,6 +4,9 @@ import (
 	"errors"
 	"sync"
 	"time"
+
+	"github.com/AndriyKalashnykov/gocache/internal/entrylist"
+	"github.com/AndriyKalashnykov/gocache/internal/janitor"
 )
 
 const (
@@ -55,10 +58,36 @@ type Cache struct {
 	head *Entry
 	tail *Entry
 
-	// stopJanitor is the channel used to stop the janitor
-	stopJanitor chan bool
+	// sieveHand is the "hand" pointer used by the SIEVE eviction policy. It points at the entry that
+	// will be considered for eviction next, and is only used when evictionPolicy is SIEVE.
+	sieveHand *Entry
+
+	// janitorRunner runs the background sweep started by StartJanitor
+	janitorRunner janitor.Runner
 
 	memoryUsage int
+
+	// loaders tracks in-flight GetOrLoad calls so that concurrent callers for the same missing
+	// key coalesce onto a single loader call instead of each calling it themselves
+	loaders      map[string]*loadCall
+	loadersMutex sync.Mutex
+
+	// onEvict, if set, is called whenever an entry is evicted to make room for new entries
+	onEvict func(key string, value interface{}, reason EvictionReason)
+
+	// onExpire, if set, is called whenever an entry is removed because its TTL elapsed
+	onExpire func(key string, value interface{})
+}
+
+// pendingEviction carries the key/value/reason of an entry that evict or evictSieve just removed from
+// the cache, so that the caller can notify onEvict once cache.mutex has been released. onEvict must
+// never be invoked while the lock is held, since a callback that calls back into the cache (e.g. Get,
+// Set, or another InvalidateFn to cascade-invalidate a related key) would otherwise deadlock on the
+// non-reentrant sync.RWMutex.
+type pendingEviction struct {
+	key    string
+	value  interface{}
+	reason EvictionReason
 }
 
 // MaxSize returns the maximum amount of keys that can be present in the cache before
@@ -112,6 +141,22 @@ func (cache *Cache) WithEvictionPolicy(policy EvictionPolicy) *Cache {
 	return cache
 }
 
+// WithOnEvict sets the function called whenever an entry is evicted to make room for new entries,
+// i.e. from evict(). fn is always called after cache.mutex has been released, so it may safely call
+// back into the cache (e.g. to cascade-invalidate a related key) without deadlocking.
+func (cache *Cache) WithOnEvict(fn func(key string, value interface{}, reason EvictionReason)) *Cache {
+	cache.onEvict = fn
+	return cache
+}
+
+// WithOnExpire sets the function called whenever an entry is removed because its TTL elapsed, whether
+// that's discovered lazily by Get or proactively by the janitor. fn is always called after
+// cache.mutex has been released, so it may safely call back into the cache without deadlocking.
+func (cache *Cache) WithOnExpire(fn func(key string, value interface{})) *Cache {
+	cache.onExpire = fn
+	return cache
+}
+
 // NewCache creates a new Cache
 //
 // Should be used in conjunction with Cache.WithMaxSize, Cache.WithMaxMemoryUsage and/or Cache.WithEvictionPolicy
@@ -125,7 +170,7 @@ func NewCache() *Cache {
 		stats:          &Statistics{},
 		entries:        make(map[string]*Entry),
 		mutex:          sync.RWMutex{},
-		stopJanitor:    nil,
+		loaders:        make(map[string]*loadCall),
 	}
 }
 
@@ -187,17 +232,27 @@ func (cache *Cache) SetWithTTL(key string, value interface{}, ttl time.Duration)
 		cache.mutex.Unlock()
 		return
 	}
+	var evictions []pendingEviction
 	// If there's a maxSize and the cache has more entries than the maxSize, evict
 	if cache.maxSize != NoMaxSize && len(cache.entries) > cache.maxSize {
-		cache.evict()
+		if key, value, ok := cache.evict(); ok {
+			evictions = append(evictions, pendingEviction{key, value, EvictionReasonMaxSizeReached})
+		}
 	}
 	// If there's a maxMemoryUsage and the memoryUsage is above the maxMemoryUsage, evict
 	if cache.maxMemoryUsage != NoMaxMemoryUsage && cache.memoryUsage > cache.maxMemoryUsage {
 		for cache.memoryUsage > cache.maxMemoryUsage && len(cache.entries) > 0 {
-			cache.evict()
+			if key, value, ok := cache.evict(); ok {
+				evictions = append(evictions, pendingEviction{key, value, EvictionReasonMaxMemoryUsageReached})
+			}
 		}
 	}
 	cache.mutex.Unlock()
+	if cache.onEvict != nil {
+		for _, e := range evictions {
+			cache.onEvict(e.key, e.value, e.reason)
+		}
+	}
 }
 
 // SetAll creates or updates multiple values
@@ -214,14 +269,18 @@ func (cache *Cache) Get(key string) (interface{}, bool) {
 	cache.mutex.Lock()
 	entry, ok := cache.get(key)
 	if !ok {
-		cache.mutex.Unlock()
 		cache.stats.Misses++
+		cache.mutex.Unlock()
 		return nil, false
 	}
 	cache.stats.Hits++
 	if entry.Expired() {
+		value := entry.Value
 		cache.delete(key)
 		cache.mutex.Unlock()
+		if cache.onExpire != nil {
+			cache.onExpire(key, value)
+		}
 		return nil, false
 	}
 	if cache.evictionPolicy == LeastRecentlyUsed {
@@ -232,6 +291,10 @@ func (cache *Cache) Get(key string) (interface{}, bool) {
 		}
 		// Because the eviction policy is LRU, we need to move the entry back to HEAD
 		cache.moveExistingEntryToHead(entry)
+	} else if cache.evictionPolicy == SIEVE {
+		// Unlike LRU, SIEVE doesn't relink the entry on access, it just flags it as visited so that
+		// it gets a second chance the next time the eviction hand passes over it
+		entry.visited = true
 	}
 	cache.mutex.Unlock()
 	return entry.Value, true
@@ -313,6 +376,7 @@ func (cache *Cache) Clear() {
 	cache.memoryUsage = 0
 	cache.head = nil
 	cache.tail = nil
+	cache.sieveHand = nil
 	cache.mutex.Unlock()
 }
 
@@ -370,6 +434,9 @@ func (cache *Cache) delete(key string) bool {
 		if cache.maxMemoryUsage != NoMaxMemoryUsage {
 			cache.memoryUsage -= entry.SizeInBytes()
 		}
+		if cache.sieveHand == entry {
+			cache.sieveHand = entry.previous
+		}
 		cache.removeExistingEntryReferences(entry)
 		delete(cache.entries, key)
 	}
@@ -378,53 +445,64 @@ func (cache *Cache) delete(key string) bool {
 
 // moveExistingEntryToHead replaces the current cache head for an existing entry
 func (cache *Cache) moveExistingEntryToHead(entry *Entry) {
-	if !(entry == cache.head && entry == cache.tail) {
-		cache.removeExistingEntryReferences(entry)
-	}
-	if entry != cache.head {
-		entry.previous = cache.head
-		entry.next = nil
-		if cache.head != nil {
-			cache.head.next = entry
-		}
-		cache.head = entry
-	}
+	entrylist.MoveToHead(&cache.head, &cache.tail, (*Entry)(nil), entry)
 }
 
 // removeExistingEntryReferences modifies the next and previous reference of an existing entry and re-links
 // the next and previous entry accordingly, as well as the cache head or/and the cache tail if necessary.
 // Note that it does not remove the entry from the cache, only the references.
 func (cache *Cache) removeExistingEntryReferences(entry *Entry) {
-	if cache.tail == entry && cache.head == entry {
-		cache.tail = nil
-		cache.head = nil
-	} else if cache.tail == entry {
-		cache.tail = cache.tail.next
-	} else if cache.head == entry {
-		cache.head = cache.head.previous
+	entrylist.RemoveReferences(&cache.head, &cache.tail, (*Entry)(nil), entry)
+}
+
+// evict removes an entry from the cache according to the configured EvictionPolicy.
+//
+// It returns the key and value of the evicted entry and true, or "", nil and false if there was
+// nothing to evict. The caller is responsible for invoking onEvict (if set) once cache.mutex has been
+// released; see pendingEviction.
+func (cache *Cache) evict() (string, interface{}, bool) {
+	if cache.tail == nil || len(cache.entries) == 0 {
+		return "", nil, false
 	}
-	if entry.previous != nil {
-		entry.previous.next = entry.next
+	if cache.evictionPolicy == SIEVE {
+		return cache.evictSieve()
 	}
-	if entry.next != nil {
-		entry.next.previous = entry.previous
+	oldTail := cache.tail
+	cache.removeExistingEntryReferences(oldTail)
+	delete(cache.entries, oldTail.Key)
+	if cache.maxMemoryUsage != NoMaxMemoryUsage {
+		cache.memoryUsage -= oldTail.SizeInBytes()
 	}
-	entry.next = nil
-	entry.previous = nil
+	cache.stats.EvictedKeys++
+	return oldTail.Key, oldTail.Value, true
 }
 
-// evict removes the tail from the cache
-func (cache *Cache) evict() {
-	if cache.tail == nil || len(cache.entries) == 0 {
-		return
+// evictSieve evicts an entry according to the SIEVE algorithm: the hand walks from the tail towards
+// the head, giving a second chance to every entry it finds visited, and evicting the first entry it
+// finds that hasn't been visited since the last time the hand passed over it.
+//
+// See evict for the meaning of the returned values.
+func (cache *Cache) evictSieve() (string, interface{}, bool) {
+	current := cache.sieveHand
+	if current == nil {
+		current = cache.tail
 	}
-	if cache.tail != nil {
-		oldTail := cache.tail
-		cache.removeExistingEntryReferences(oldTail)
-		delete(cache.entries, oldTail.Key)
-		if cache.maxMemoryUsage != NoMaxMemoryUsage {
-			cache.memoryUsage -= oldTail.SizeInBytes()
+	for current != nil && current.visited {
+		current.visited = false
+		current = current.next
+		if current == nil {
+			current = cache.tail
 		}
-		cache.stats.EvictedKeys++
 	}
+	if current == nil {
+		return "", nil, false
+	}
+	cache.sieveHand = current.previous
+	cache.removeExistingEntryReferences(current)
+	delete(cache.entries, current.Key)
+	if cache.maxMemoryUsage != NoMaxMemoryUsage {
+		cache.memoryUsage -= current.SizeInBytes()
+	}
+	cache.stats.EvictedKeys++
+	return current.Key, current.Value, true
 }
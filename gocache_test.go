@@ -0,0 +1,58 @@
+package gocache
+
+import "testing"
+
+func TestCache_ClearResetsSieveHand(t *testing.T) {
+	var evictedKeys []string
+	cache := NewCache().WithMaxSize(3).WithEvictionPolicy(SIEVE).WithOnEvict(func(key string, value interface{}, reason EvictionReason) {
+		evictedKeys = append(evictedKeys, key)
+	})
+	cache.Set("key1", 1)
+	cache.Set("key2", 2)
+	cache.Set("key3", 3)
+	// Accessing key1 and key2 marks them as visited, so the SIEVE hand will skip over them and
+	// evict key3, leaving cache.sieveHand pointing at key2 (key3's previous entry) afterwards.
+	cache.Get("key1")
+	cache.Get("key2")
+	cache.Set("key4", 4) // forces an eviction, advancing the SIEVE hand
+	cache.Clear()
+	if cache.sieveHand != nil {
+		t.Fatal("expected sieveHand to be reset to nil after Clear")
+	}
+	evictedKeys = nil
+	cache.Set("key5", 5)
+	cache.Set("key6", 6)
+	cache.Set("key7", 7)
+	cache.Set("key8", 8) // exceeds MaxSize of 3, so this should trigger an eviction
+	if cache.Count() != 3 {
+		t.Errorf("expected Count() to be 3 after MaxSize was exceeded post-Clear, but got %d", cache.Count())
+	}
+	if len(evictedKeys) != 1 || evictedKeys[0] != "key5" {
+		t.Errorf("expected key5 to have been evicted, but got %v", evictedKeys)
+	}
+}
+
+func TestCache_SIEVEEviction(t *testing.T) {
+	var evictedKeys []string
+	cache := NewCache().WithMaxSize(3).WithEvictionPolicy(SIEVE).WithOnEvict(func(key string, value interface{}, reason EvictionReason) {
+		evictedKeys = append(evictedKeys, key)
+	})
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Set("c", 3)
+	// Mark "a" as visited so that it's given a second chance instead of being the one evicted
+	cache.Get("a")
+	cache.Set("d", 4) // exceeds MaxSize of 3: the hand starts at the tail ("a"), skips it because it was
+	// visited, and evicts "b" instead, since "b" is the next entry towards the head and was never visited
+	if len(evictedKeys) != 1 || evictedKeys[0] != "b" {
+		t.Fatalf("expected b to have been evicted, but got %v", evictedKeys)
+	}
+	for _, key := range []string{"a", "c", "d"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("expected key %s to still be present", key)
+		}
+	}
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected b to have been evicted")
+	}
+}
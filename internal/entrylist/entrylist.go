@@ -0,0 +1,52 @@
+// Package entrylist implements the doubly-linked list relinking logic shared by the non-generic Cache
+// and the generic Cache[K, V], so that the two don't have to maintain two copies of the same list
+// bookkeeping. The list runs from tail (oldest/least relevant) to head (newest/most relevant).
+package entrylist
+
+// Node is the set of accessors a cache entry must implement to be managed by MoveToHead/RemoveReferences.
+type Node[N comparable] interface {
+	comparable
+	Previous() N
+	SetPrevious(N)
+	Next() N
+	SetNext(N)
+}
+
+// MoveToHead relinks entry so that it becomes *head, first removing it from its current position if it
+// already has one. zero is N's zero value (e.g. a nil *Entry), since the zero value can't be spelled
+// generically as a literal.
+func MoveToHead[N Node[N]](head, tail *N, zero N, entry N) {
+	if !(entry == *head && entry == *tail) {
+		RemoveReferences(head, tail, zero, entry)
+	}
+	if entry != *head {
+		entry.SetPrevious(*head)
+		entry.SetNext(zero)
+		if *head != zero {
+			(*head).SetNext(entry)
+		}
+		*head = entry
+	}
+}
+
+// RemoveReferences unlinks entry from the list referenced by head/tail, relinking its neighbours and
+// updating head/tail if entry was one of them. It does not remove entry from any lookup map/index;
+// the caller is responsible for that.
+func RemoveReferences[N Node[N]](head, tail *N, zero N, entry N) {
+	if *tail == entry && *head == entry {
+		*tail = zero
+		*head = zero
+	} else if *tail == entry {
+		*tail = entry.Next()
+	} else if *head == entry {
+		*head = entry.Previous()
+	}
+	if entry.Previous() != zero {
+		entry.Previous().SetNext(entry.Next())
+	}
+	if entry.Next() != zero {
+		entry.Next().SetPrevious(entry.Previous())
+	}
+	entry.SetNext(zero)
+	entry.SetPrevious(zero)
+}
@@ -0,0 +1,56 @@
+// Package janitor implements the background sweep loop shared by the non-generic Cache and the generic
+// Cache[K, V]'s StartJanitor/StopJanitor, so that the two don't have to maintain two copies of the same
+// ticker/stop-channel bookkeeping.
+package janitor
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrAlreadyRunning is returned by Start when the janitor is already running.
+var ErrAlreadyRunning = errors.New("janitor is already running")
+
+// Runner periodically invokes a sweep function in the background until stopped.
+type Runner struct {
+	mutex sync.Mutex
+	stop  chan bool
+}
+
+// Start starts a background goroutine that invokes sweep at the given interval, until Stop is called.
+//
+// Returns ErrAlreadyRunning if the janitor is already running.
+func (r *Runner) Start(interval time.Duration, sweep func()) error {
+	r.mutex.Lock()
+	if r.stop != nil {
+		r.mutex.Unlock()
+		return ErrAlreadyRunning
+	}
+	stop := make(chan bool)
+	r.stop = stop
+	r.mutex.Unlock()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				sweep()
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops the janitor, if one is running
+func (r *Runner) Stop() {
+	r.mutex.Lock()
+	if r.stop != nil {
+		close(r.stop)
+		r.stop = nil
+	}
+	r.mutex.Unlock()
+}
@@ -0,0 +1,51 @@
+package gocache
+
+// EvictionReason indicates why an entry was evicted from the cache. It is passed to the function
+// registered with Cache.WithOnEvict.
+type EvictionReason int
+
+const (
+	// EvictionReasonMaxSizeReached means that the entry was evicted because the cache had more
+	// entries than its configured maxSize
+	EvictionReasonMaxSizeReached EvictionReason = iota
+
+	// EvictionReasonMaxMemoryUsageReached means that the entry was evicted because the cache's
+	// memoryUsage exceeded its configured maxMemoryUsage
+	EvictionReasonMaxMemoryUsageReached
+)
+
+// InvalidateFn deletes every key in the cache for which fn returns true
+//
+// fn is always called without cache.mutex held, so it may safely call back into the cache (e.g. to
+// cascade-invalidate a related key) without deadlocking. This means InvalidateFn is not a consistent
+// point-in-time snapshot-and-delete: it walks a snapshot of the keys present when it started, so a key
+// set after that snapshot was taken won't be considered, and a key that's deleted and recreated while
+// the walk is in progress may be invalidated under its new value.
+//
+// Returns the number of keys deleted
+func (cache *Cache) InvalidateFn(fn func(key string) bool) int {
+	cache.mutex.RLock()
+	keys := make([]string, 0, len(cache.entries))
+	for key := range cache.entries {
+		keys = append(keys, key)
+	}
+	cache.mutex.RUnlock()
+	numberOfKeysDeleted := 0
+	for _, key := range keys {
+		if fn(key) && cache.Delete(key) {
+			numberOfKeysDeleted++
+		}
+	}
+	return numberOfKeysDeleted
+}
+
+// InvalidateByPattern deletes every key in the cache that matches pattern
+//
+// See GetKeysByPattern for the pattern syntax
+//
+// Returns the number of keys deleted
+func (cache *Cache) InvalidateByPattern(pattern string) int {
+	return cache.InvalidateFn(func(key string) bool {
+		return MatchPattern(pattern, key)
+	})
+}
@@ -0,0 +1,127 @@
+package gocache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCache_InvalidateFn(t *testing.T) {
+	cache := NewCache()
+	cache.Set("fruit:apple", 1)
+	cache.Set("fruit:banana", 2)
+	cache.Set("vegetable:carrot", 3)
+	numberOfKeysDeleted := cache.InvalidateFn(func(key string) bool {
+		return key == "fruit:apple" || key == "fruit:banana"
+	})
+	if numberOfKeysDeleted != 2 {
+		t.Errorf("expected 2 keys to have been deleted, but got %d", numberOfKeysDeleted)
+	}
+	if cache.Count() != 1 {
+		t.Errorf("expected 1 key to remain, but got %d", cache.Count())
+	}
+}
+
+func TestCache_InvalidateByPattern(t *testing.T) {
+	cache := NewCache()
+	cache.Set("fruit:apple", 1)
+	cache.Set("fruit:banana", 2)
+	cache.Set("vegetable:carrot", 3)
+	numberOfKeysDeleted := cache.InvalidateByPattern("fruit:*")
+	if numberOfKeysDeleted != 2 {
+		t.Errorf("expected 2 keys to have been deleted, but got %d", numberOfKeysDeleted)
+	}
+	if _, ok := cache.Get("vegetable:carrot"); !ok {
+		t.Error("expected vegetable:carrot to still be in the cache")
+	}
+}
+
+func TestCache_WithOnEvictAndWithOnExpire(t *testing.T) {
+	var evictedKeys []string
+	var expiredKeys []string
+	cache := NewCache().WithMaxSize(1).WithOnEvict(func(key string, value interface{}, reason EvictionReason) {
+		evictedKeys = append(evictedKeys, key)
+	}).WithOnExpire(func(key string, value interface{}) {
+		expiredKeys = append(expiredKeys, key)
+	})
+	cache.Set("key1", 1)
+	cache.Set("key2", 2)
+	if len(evictedKeys) != 1 || evictedKeys[0] != "key1" {
+		t.Errorf("expected key1 to have been evicted, but got %v", evictedKeys)
+	}
+	cache.SetWithTTL("key3", 3, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if _, ok := cache.Get("key3"); ok {
+		t.Error("key3 should've expired by now")
+	}
+	if len(expiredKeys) != 1 || expiredKeys[0] != "key3" {
+		t.Errorf("expected key3 to have been reported as expired, but got %v", expiredKeys)
+	}
+}
+
+func TestCache_WithOnEvictCanCallBackIntoCacheWithoutDeadlocking(t *testing.T) {
+	cache := NewCache().WithMaxSize(2)
+	cache.WithOnEvict(func(key string, value interface{}, reason EvictionReason) {
+		// A real cache-coherence hook might cascade-invalidate a related key, which re-enters the
+		// cache's mutex; this must not deadlock now that onEvict fires after the lock is released.
+		cache.Delete("related:" + key)
+	})
+	cache.Set("key1", 1)
+	cache.Set("related:key1", 1)
+	done := make(chan struct{})
+	go func() {
+		cache.Set("key2", 2) // evicts key1, triggering the onEvict callback above
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Set deadlocked; onEvict must have been invoked while cache.mutex was still held")
+	}
+	if _, ok := cache.Get("related:key1"); ok {
+		t.Error("expected related:key1 to have been deleted by the onEvict callback")
+	}
+}
+
+func TestCache_InvalidateFnPredicateCanCallBackIntoCacheWithoutDeadlocking(t *testing.T) {
+	cache := NewCache()
+	cache.Set("fruit:apple", 1)
+	cache.Set("fruit:banana", 2)
+	done := make(chan struct{})
+	go func() {
+		cache.InvalidateFn(func(key string) bool {
+			// Calling back into the cache from within the predicate must not deadlock now that fn is
+			// called without cache.mutex held.
+			cache.Get(key)
+			return true
+		})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("InvalidateFn deadlocked; fn must have been called while cache.mutex was still held")
+	}
+	if cache.Count() != 0 {
+		t.Errorf("expected all keys to have been deleted, but got %d remaining", cache.Count())
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	scenarios := []struct {
+		pattern  string
+		key      string
+		expected bool
+	}{
+		{"*", "anything", true},
+		{"fruit:*", "fruit:apple", true},
+		{"fruit:*", "vegetable:carrot", false},
+		{"*some*", "awesome", true},
+		{"exact", "exact", true},
+		{"exact", "not-exact", false},
+	}
+	for _, scenario := range scenarios {
+		if MatchPattern(scenario.pattern, scenario.key) != scenario.expected {
+			t.Errorf("MatchPattern(%s, %s) should've returned %v", scenario.pattern, scenario.key, scenario.expected)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package gocache
+
+import "time"
+
+// StartJanitor starts a background task that periodically deletes every expired entry from the cache,
+// at the interval specified by the given duration.
+//
+// Returns ErrJanitorAlreadyRunning if the janitor is already running.
+func (cache *Cache) StartJanitor(interval time.Duration) error {
+	if err := cache.janitorRunner.Start(interval, cache.expireEntries); err != nil {
+		return ErrJanitorAlreadyRunning
+	}
+	return nil
+}
+
+// StopJanitor stops the janitor, if one is running
+func (cache *Cache) StopJanitor() {
+	cache.janitorRunner.Stop()
+}
+
+// expireEntries deletes every entry in the cache that has expired, notifying onExpire for each one
+// once cache.mutex has been released, so that onExpire may safely call back into the cache.
+func (cache *Cache) expireEntries() {
+	type expiredEntry struct {
+		key   string
+		value interface{}
+	}
+	var expired []expiredEntry
+	cache.mutex.Lock()
+	for key, entry := range cache.entries {
+		if entry.Expired() {
+			expired = append(expired, expiredEntry{key: key, value: entry.Value})
+			cache.delete(key)
+		}
+	}
+	cache.mutex.Unlock()
+	if cache.onExpire != nil {
+		for _, e := range expired {
+			cache.onExpire(e.key, e.value)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package gocache
+
+import (
+	"fmt"
+	"time"
+)
+
+// loadCall represents an in-flight or completed GetOrLoad call for a single key
+type loadCall struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// GetOrLoad retrieves the entry identified by key, or, if it doesn't exist (or has expired), invokes
+// loader to compute it, caches the result with the given ttl and returns it.
+//
+// If multiple goroutines call GetOrLoad for the same missing key concurrently, loader is only invoked
+// once: the other callers block until the first call completes and then receive the same result. This
+// mirrors the Guava-style LoadingCache pattern and avoids the thundering-herd problem of a manual
+// Get-then-Set.
+//
+// If loader returns an error, every waiter for that call receives the same error and nothing is cached.
+func (cache *Cache) GetOrLoad(key string, ttl time.Duration, loader func() (interface{}, error)) (interface{}, error) {
+	if value, ok := cache.Get(key); ok {
+		return value, nil
+	}
+	cache.loadersMutex.Lock()
+	if call, ok := cache.loaders[key]; ok {
+		cache.loadersMutex.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+	// A call for key may have completed between the Get above and taking loadersMutex, in which case
+	// its result is already cached and loader must not be invoked again
+	cache.mutex.RLock()
+	if entry, ok := cache.get(key); ok && !entry.Expired() {
+		value := entry.Value
+		cache.mutex.RUnlock()
+		cache.loadersMutex.Unlock()
+		return value, nil
+	}
+	cache.mutex.RUnlock()
+	call := &loadCall{done: make(chan struct{})}
+	cache.loaders[key] = call
+	cache.loadersMutex.Unlock()
+
+	// If loader panics below, this still has to run so that other goroutines blocked on call.done (and
+	// any future GetOrLoad call for key) don't hang forever waiting on a call that will never complete.
+	defer func() {
+		r := recover()
+		if r != nil {
+			call.err = fmt.Errorf("loader panicked: %v", r)
+		}
+		cache.loadersMutex.Lock()
+		delete(cache.loaders, key)
+		cache.loadersMutex.Unlock()
+		close(call.done)
+		if r != nil {
+			panic(r)
+		}
+	}()
+
+	call.value, call.err = loader()
+	if call.err == nil {
+		cache.SetWithTTL(key, call.value, ttl)
+	}
+	return call.value, call.err
+}
@@ -0,0 +1,125 @@
+package gocache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCache_GetOrLoad(t *testing.T) {
+	cache := NewCache()
+	var calls int32
+	value, err := cache.GetOrLoad("key", NoExpiration, func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return "value", nil
+	})
+	if err != nil {
+		t.Fatal("shouldn't have returned an error, but got:", err.Error())
+	}
+	if value != "value" {
+		t.Errorf("expected value to be 'value', but got '%v'", value)
+	}
+	// The entry should now be cached, so calling GetOrLoad again shouldn't invoke loader
+	value, err = cache.GetOrLoad("key", NoExpiration, func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return "value", nil
+	})
+	if err != nil {
+		t.Fatal("shouldn't have returned an error, but got:", err.Error())
+	}
+	if value != "value" {
+		t.Errorf("expected value to be 'value', but got '%v'", value)
+	}
+	if calls != 1 {
+		t.Errorf("expected loader to have been called once, but it was called %d times", calls)
+	}
+}
+
+func TestCache_GetOrLoadCoalescesConcurrentCalls(t *testing.T) {
+	cache := NewCache()
+	var calls int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := cache.GetOrLoad("key", NoExpiration, func() (interface{}, error) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(10 * time.Millisecond)
+				return "value", nil
+			})
+			if err != nil {
+				t.Error("shouldn't have returned an error, but got:", err.Error())
+			}
+			if value != "value" {
+				t.Errorf("expected value to be 'value', but got '%v'", value)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls != 1 {
+		t.Errorf("expected loader to have been called exactly once despite concurrent callers, but it was called %d times", calls)
+	}
+}
+
+func TestCache_GetOrLoadReturnsLoaderError(t *testing.T) {
+	cache := NewCache()
+	loaderErr := errors.New("failed to load")
+	_, err := cache.GetOrLoad("key", NoExpiration, func() (interface{}, error) {
+		return nil, loaderErr
+	})
+	if err != loaderErr {
+		t.Errorf("expected err to be %v, but got %v", loaderErr, err)
+	}
+	if _, ok := cache.Get("key"); ok {
+		t.Error("expected key not to have been cached since loader returned an error")
+	}
+}
+
+func TestCache_GetOrLoadUnblocksWaitersWhenLoaderPanics(t *testing.T) {
+	cache := NewCache()
+	var wg sync.WaitGroup
+	var waiterErrors int32
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				// The goroutine that actually calls loader is the only one expected to re-panic
+				recover()
+			}()
+			_, err := cache.GetOrLoad("key", NoExpiration, func() (interface{}, error) {
+				time.Sleep(10 * time.Millisecond)
+				panic("loader blew up")
+			})
+			if err != nil {
+				atomic.AddInt32(&waiterErrors, 1)
+			}
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("waiters never unblocked after loader panicked; key is permanently wedged")
+	}
+	if waiterErrors == 0 {
+		t.Error("expected at least one waiter to have received an error instead of hanging")
+	}
+	// The key should be usable again since the failed call was cleaned up from cache.loaders
+	value, err := cache.GetOrLoad("key", NoExpiration, func() (interface{}, error) {
+		return "recovered", nil
+	})
+	if err != nil {
+		t.Fatal("shouldn't have returned an error, but got:", err.Error())
+	}
+	if value != "recovered" {
+		t.Errorf("expected value to be 'recovered', but got '%v'", value)
+	}
+}
@@ -0,0 +1,32 @@
+package gocache
+
+// MatchPattern checks whether key matches pattern, where pattern may contain the '*' wildcard to match
+// any sequence of characters (including none). Matching is case-sensitive.
+//
+// e.g. MatchPattern("*some*", "awesome") returns true
+func MatchPattern(pattern, key string) bool {
+	patternIndex, keyIndex := 0, 0
+	starIndex, matchIndex := -1, 0
+	for keyIndex < len(key) {
+		if patternIndex < len(pattern) && (pattern[patternIndex] == '*' || pattern[patternIndex] == key[keyIndex]) {
+			if pattern[patternIndex] == '*' {
+				starIndex = patternIndex
+				matchIndex = keyIndex
+				patternIndex++
+			} else {
+				patternIndex++
+				keyIndex++
+			}
+		} else if starIndex != -1 {
+			patternIndex = starIndex + 1
+			matchIndex++
+			keyIndex = matchIndex
+		} else {
+			return false
+		}
+	}
+	for patternIndex < len(pattern) && pattern[patternIndex] == '*' {
+		patternIndex++
+	}
+	return patternIndex == len(pattern)
+}
@@ -2,23 +2,56 @@ package gocache
 
 import (
 	"bufio"
-	"encoding/gob"
+	"bytes"
+	"errors"
+	"io"
 	"os"
 	"sort"
 )
 
-// SaveToFile stores the content of the cache to a file so that it can be read using
-// the ReadFromFile function
+// fileMagicNumber identifies a file written by SaveToFile/SaveToWriter, so that ReadFromFile/
+// ReadFromReader can reject a file that isn't a gocache file (or that was truncated/corrupted) with a
+// clear error instead of attempting to decode it and producing garbage entries.
+var fileMagicNumber = [4]byte{'G', 'O', 'C', 'H'}
+
+// fileFormatVersion is the version of the header written by SaveToFile/SaveToWriter. It is bumped
+// whenever the header (not the codec payload that follows it) changes shape.
+const fileFormatVersion uint8 = 1
+
+var (
+	// ErrInvalidFileFormat is returned by ReadFromFile/ReadFromReader when the source doesn't start
+	// with the gocache magic number, meaning it wasn't created by SaveToFile/SaveToWriter
+	ErrInvalidFileFormat = errors.New("source does not start with the gocache magic number")
+
+	// ErrUnsupportedFileFormatVersion is returned by ReadFromFile/ReadFromReader when the source was
+	// written using a file format version that this version of gocache doesn't know how to read
+	ErrUnsupportedFileFormatVersion = errors.New("source was written using an unsupported gocache file format version")
+)
+
+// SaveToFile stores the content of the cache to a file, encoded using GobCodec, so that it can be
+// read using the ReadFromFile function
 func (cache *Cache) SaveToFile(path string) error {
 	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
-	writer := bufio.NewWriter(file)
-	encoder := gob.NewEncoder(writer)
+	return cache.SaveToWriter(file, GobCodec{})
+}
+
+// SaveToWriter stores the content of the cache to w, encoded using codec. This allows persisting the
+// cache anywhere an io.Writer can point to, such as a compressed stream or a network socket, rather
+// than only to a local file.
+func (cache *Cache) SaveToWriter(w io.Writer, codec Codec) error {
+	writer := bufio.NewWriter(w)
+	if _, err := writer.Write(fileMagicNumber[:]); err != nil {
+		return err
+	}
+	if err := writer.WriteByte(fileFormatVersion); err != nil {
+		return err
+	}
 	cache.mutex.RLock()
-	err = encoder.Encode(cache.entries)
+	err := codec.Encode(writer, cache.entries)
 	cache.mutex.RUnlock()
 	if err != nil {
 		return err
@@ -26,7 +59,8 @@ func (cache *Cache) SaveToFile(path string) error {
 	return writer.Flush()
 }
 
-// ReadFromFile populates the cache using a file created using cache.SaveToFile(path)
+// ReadFromFile populates the cache using a file created using cache.SaveToFile(path), decoding it
+// using GobCodec.
 //
 // Note that if the number of entries retrieved from the file exceed the configured maxSize,
 // the extra entries will be automatically evicted according to the EvictionPolicy configured.
@@ -39,11 +73,33 @@ func (cache *Cache) ReadFromFile(path string) (int, error) {
 		return 0, err
 	}
 	defer file.Close()
-	reader := bufio.NewReader(file)
-	decoder := gob.NewDecoder(reader)
+	return cache.ReadFromReader(file, GobCodec{})
+}
+
+// ReadFromReader populates the cache using r, which must have been written by SaveToWriter using the
+// same codec. This allows reading the cache back from anywhere an io.Reader can point to.
+//
+// See ReadFromFile for details on the returned eviction count.
+func (cache *Cache) ReadFromReader(r io.Reader, codec Codec) (int, error) {
+	reader := bufio.NewReader(r)
+	var magicNumber [4]byte
+	if _, err := io.ReadFull(reader, magicNumber[:]); err != nil {
+		return 0, err
+	}
+	if !bytes.Equal(magicNumber[:], fileMagicNumber[:]) {
+		return 0, ErrInvalidFileFormat
+	}
+	version, err := reader.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if version != fileFormatVersion {
+		return 0, ErrUnsupportedFileFormatVersion
+	}
 	cache.mutex.Lock()
-	err = decoder.Decode(&cache.entries)
+	err = codec.Decode(reader, &cache.entries)
 	if err != nil {
+		cache.mutex.Unlock()
 		return 0, err
 	}
 	// Because pointers don't get stored in the file, we need to relink everything from head to tail
@@ -80,20 +136,30 @@ func (cache *Cache) ReadFromFile(path string) (int, error) {
 	}
 	// Evict what needs to be evicted
 	numberOfEvictions := 0
+	var evictions []pendingEviction
 	// If there's a maxSize and the cache has more entries than the maxSize, evict
 	if cache.maxSize != NoMaxSize && len(cache.entries) > cache.maxSize {
 		for len(cache.entries) > cache.maxSize {
 			numberOfEvictions++
-			cache.evict()
+			if key, value, ok := cache.evict(); ok {
+				evictions = append(evictions, pendingEviction{key, value, EvictionReasonMaxSizeReached})
+			}
 		}
 	}
 	// If there's a maxMemoryUsage and the memoryUsage is above the maxMemoryUsage, evict
 	if cache.maxMemoryUsage != NoMaxMemoryUsage && cache.memoryUsage > cache.maxMemoryUsage {
 		for cache.memoryUsage > cache.maxMemoryUsage && len(cache.entries) > 0 {
 			numberOfEvictions++
-			cache.evict()
+			if key, value, ok := cache.evict(); ok {
+				evictions = append(evictions, pendingEviction{key, value, EvictionReasonMaxMemoryUsageReached})
+			}
 		}
 	}
 	cache.mutex.Unlock()
+	if cache.onEvict != nil {
+		for _, e := range evictions {
+			cache.onEvict(e.key, e.value, e.reason)
+		}
+	}
 	return numberOfEvictions, nil
 }
@@ -1,6 +1,7 @@
 package gocache
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"strconv"
@@ -8,6 +9,9 @@ import (
 	"time"
 )
 
+// TestCacheFile is the path used by persistence tests to save/read the cache to/from disk
+const TestCacheFile = "TestCacheFile.cache"
+
 func TestCache_SaveToFile(t *testing.T) {
 	defer os.Remove(TestCacheFile)
 	cache := NewCache()
@@ -83,3 +87,31 @@ func TestCache_ReadFromFile(t *testing.T) {
 	// Make sure we can create new entries
 	newCache.Set("eviction-test", 1)
 }
+
+func TestCache_SaveToWriterAndReadFromReaderWithJSONCodec(t *testing.T) {
+	cache := NewCache()
+	cache.Set("key1", "value1")
+	cache.Set("key2", "value2")
+	var buffer bytes.Buffer
+	if err := cache.SaveToWriter(&buffer, JSONCodec{}); err != nil {
+		t.Fatal("shouldn't have returned an error, but got:", err.Error())
+	}
+	newCache := NewCache()
+	if _, err := newCache.ReadFromReader(&buffer, JSONCodec{}); err != nil {
+		t.Fatal("shouldn't have returned an error, but got:", err.Error())
+	}
+	if newCache.Count() != 2 {
+		t.Error("expected newCache to have 2 entries, but got", newCache.Count())
+	}
+	if value, _ := newCache.Get("key1"); value != "value1" {
+		t.Errorf("expected key1 to have value 'value1', but got '%v'", value)
+	}
+}
+
+func TestCache_ReadFromReaderWithInvalidMagicNumber(t *testing.T) {
+	cache := NewCache()
+	_, err := cache.ReadFromReader(bytes.NewReader([]byte("not-a-gocache-file")), GobCodec{})
+	if err != ErrInvalidFileFormat {
+		t.Errorf("expected ErrInvalidFileFormat, but got: %v", err)
+	}
+}
@@ -0,0 +1,292 @@
+package gocache
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/gob"
+	"hash/fnv"
+	"os"
+	"sort"
+	"time"
+)
+
+// ShardedCache wraps multiple independent Cache instances ("shards") and routes each key to a shard
+// using FNV-1a hashing, the same approach used by bigcache and similar libraries. This spreads reads
+// and writes across multiple sync.RWMutex instances instead of a single one, which reduces lock
+// contention under heavy concurrent use.
+//
+// Aside from sharding, a ShardedCache behaves like a Cache: MaxSize and MaxMemoryUsage passed to
+// WithMaxSize/WithMaxMemoryUsage are the totals across all shards, and are divided evenly between them.
+type ShardedCache struct {
+	shards []*Cache
+}
+
+// NewShardedCache creates a new ShardedCache made up of numberOfShards independent Cache instances
+//
+// Should be used in conjunction with ShardedCache.WithMaxSize, ShardedCache.WithMaxMemoryUsage and/or
+// ShardedCache.WithEvictionPolicy
+//
+//     gocache.NewShardedCache(16).WithMaxSize(160000).WithEvictionPolicy(gocache.LeastRecentlyUsed)
+//
+func NewShardedCache(numberOfShards int) *ShardedCache {
+	if numberOfShards < 1 {
+		numberOfShards = 1
+	}
+	shards := make([]*Cache, numberOfShards)
+	for i := 0; i < numberOfShards; i++ {
+		shards[i] = NewCache()
+	}
+	return &ShardedCache{shards: shards}
+}
+
+// WithMaxSize sets the maximum amount of entries that can be in the cache at any given time, divided
+// evenly across all shards. A maxSize of 0 or less means infinite
+func (cache *ShardedCache) WithMaxSize(maxSize int) *ShardedCache {
+	if maxSize < 0 {
+		maxSize = NoMaxSize
+	}
+	perShardMaxSize := maxSize
+	if maxSize != NoMaxSize {
+		perShardMaxSize = maxSize / len(cache.shards)
+		if perShardMaxSize < 1 {
+			perShardMaxSize = 1
+		}
+	}
+	for _, shard := range cache.shards {
+		shard.WithMaxSize(perShardMaxSize)
+	}
+	return cache
+}
+
+// WithMaxMemoryUsage sets the maximum amount of memory that can be used by the cache at any given time,
+// divided evenly across all shards.
+//
+// NOTE: This is approximate.
+//
+// Setting this to NoMaxMemoryUsage will disable eviction by memory usage
+func (cache *ShardedCache) WithMaxMemoryUsage(maxMemoryUsageInBytes int) *ShardedCache {
+	if maxMemoryUsageInBytes < 0 {
+		maxMemoryUsageInBytes = NoMaxMemoryUsage
+	}
+	perShardMaxMemoryUsage := maxMemoryUsageInBytes
+	if maxMemoryUsageInBytes != NoMaxMemoryUsage {
+		perShardMaxMemoryUsage = maxMemoryUsageInBytes / len(cache.shards)
+		if perShardMaxMemoryUsage < 1 {
+			perShardMaxMemoryUsage = 1
+		}
+	}
+	for _, shard := range cache.shards {
+		shard.WithMaxMemoryUsage(perShardMaxMemoryUsage)
+	}
+	return cache
+}
+
+// WithEvictionPolicy sets the eviction algorithm on every shard
+// Defaults to FirstInFirstOut (FIFO)
+func (cache *ShardedCache) WithEvictionPolicy(policy EvictionPolicy) *ShardedCache {
+	for _, shard := range cache.shards {
+		shard.WithEvictionPolicy(policy)
+	}
+	return cache
+}
+
+// shardFor returns the shard responsible for the given key, selected by hashing the key with FNV-1a
+func (cache *ShardedCache) shardFor(key string) *Cache {
+	hasher := fnv.New32a()
+	hasher.Write([]byte(key))
+	return cache.shards[hasher.Sum32()%uint32(len(cache.shards))]
+}
+
+// Set creates or updates a key with a given value
+func (cache *ShardedCache) Set(key string, value interface{}) {
+	cache.shardFor(key).Set(key, value)
+}
+
+// SetWithTTL creates or updates a key with a given value and sets an expiration time (-1 is NoExpiration)
+func (cache *ShardedCache) SetWithTTL(key string, value interface{}, ttl time.Duration) {
+	cache.shardFor(key).SetWithTTL(key, value, ttl)
+}
+
+// Get retrieves an entry using the key passed as parameter
+// If there is no such entry, the value returned will be nil and the boolean will be false
+// If there is an entry, the value returned will be the value cached and the boolean will be true
+func (cache *ShardedCache) Get(key string) (interface{}, bool) {
+	return cache.shardFor(key).Get(key)
+}
+
+// GetKeysByPattern retrieves a slice of keys that match a given pattern, across all shards
+// If the limit is set to 0, every shard will be searched for matching keys.
+// If the limit is above 0, the search will stop once the specified number of matching keys have been found.
+func (cache *ShardedCache) GetKeysByPattern(pattern string, limit int) []string {
+	var matchingKeys []string
+	for _, shard := range cache.shards {
+		remaining := 0
+		if limit > 0 {
+			remaining = limit - len(matchingKeys)
+			if remaining <= 0 {
+				break
+			}
+		}
+		matchingKeys = append(matchingKeys, shard.GetKeysByPattern(pattern, remaining)...)
+	}
+	return matchingKeys
+}
+
+// Delete removes a key from the cache
+//
+// Returns false if the key did not exist.
+func (cache *ShardedCache) Delete(key string) bool {
+	return cache.shardFor(key).Delete(key)
+}
+
+// Count returns the total amount of entries in the cache, regardless of whether they're expired or not
+func (cache *ShardedCache) Count() int {
+	count := 0
+	for _, shard := range cache.shards {
+		count += shard.Count()
+	}
+	return count
+}
+
+// Clear deletes all entries from every shard
+func (cache *ShardedCache) Clear() {
+	for _, shard := range cache.shards {
+		shard.Clear()
+	}
+}
+
+// TTL returns the time until the cache entry specified by the key passed as parameter
+// will be deleted.
+func (cache *ShardedCache) TTL(key string) (time.Duration, error) {
+	return cache.shardFor(key).TTL(key)
+}
+
+// Expire sets a key's expiration time
+//
+// Returns true if the cache key exists and has had its expiration time altered
+func (cache *ShardedCache) Expire(key string, ttl time.Duration) bool {
+	return cache.shardFor(key).Expire(key, ttl)
+}
+
+// Stats returns statistics aggregated across every shard
+func (cache *ShardedCache) Stats() *Statistics {
+	stats := &Statistics{}
+	for _, shard := range cache.shards {
+		shardStats := shard.Stats()
+		stats.Hits += shardStats.Hits
+		stats.Misses += shardStats.Misses
+		stats.EvictedKeys += shardStats.EvictedKeys
+	}
+	return stats
+}
+
+// shardedCacheFileFormatMarker is written as the first 4 bytes of a file created by
+// ShardedCache.SaveToFile, followed by the number of shards, so that ReadFromFile can tell the
+// difference between a sharded file and one created by the non-sharded Cache.SaveToFile
+const shardedCacheFileFormatMarker uint32 = 0x67634873 // "sHcg" as a little-endian uint32
+
+// SaveToFile stores the content of every shard to a file so that it can be read using the
+// ReadFromFile function. The file starts with a header containing the number of shards, followed by
+// each shard's gob stream.
+func (cache *ShardedCache) SaveToFile(path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	writer := bufio.NewWriter(file)
+	if err := binary.Write(writer, binary.LittleEndian, shardedCacheFileFormatMarker); err != nil {
+		return err
+	}
+	if err := binary.Write(writer, binary.LittleEndian, uint32(len(cache.shards))); err != nil {
+		return err
+	}
+	encoder := gob.NewEncoder(writer)
+	for _, shard := range cache.shards {
+		shard.mutex.RLock()
+		err := encoder.Encode(shard.entries)
+		shard.mutex.RUnlock()
+		if err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+// ReadFromFile populates the cache using a file created using cache.SaveToFile(path)
+//
+// It also supports loading a file created by the non-sharded Cache.SaveToFile: every entry read from
+// the file is routed to the shard it hashes to, regardless of which shard (if any) it was originally
+// saved under, so the number of shards can safely differ between save and load.
+//
+// Returns the number of entries evicted as a result of shards exceeding their configured maxSize/
+// maxMemoryUsage while being populated.
+func (cache *ShardedCache) ReadFromFile(path string) (int, error) {
+	file, err := os.OpenFile(path, os.O_RDONLY, os.ModePerm)
+	if err != nil {
+		return 0, err
+	}
+	reader := bufio.NewReader(file)
+	var marker uint32
+	if err := binary.Read(reader, binary.LittleEndian, &marker); err != nil || marker != shardedCacheFileFormatMarker {
+		file.Close()
+		return cache.readFromNonShardedFile(path)
+	}
+	var shardCount uint32
+	if err := binary.Read(reader, binary.LittleEndian, &shardCount); err != nil {
+		file.Close()
+		return 0, err
+	}
+	decoder := gob.NewDecoder(reader)
+	evictedKeysBefore := cache.Stats().EvictedKeys
+	for i := uint32(0); i < shardCount; i++ {
+		var entries map[string]*Entry
+		if err := decoder.Decode(&entries); err != nil {
+			file.Close()
+			return int(cache.Stats().EvictedKeys - evictedKeysBefore), err
+		}
+		cache.populateFromEntries(entries)
+	}
+	file.Close()
+	return int(cache.Stats().EvictedKeys - evictedKeysBefore), nil
+}
+
+// readFromNonShardedFile reads a file created by the non-sharded Cache.SaveToFile and routes every
+// entry it contains into the appropriate shard
+func (cache *ShardedCache) readFromNonShardedFile(path string) (int, error) {
+	temporaryCache := NewCache().WithMaxSize(NoMaxSize).WithMaxMemoryUsage(NoMaxMemoryUsage)
+	if _, err := temporaryCache.ReadFromFile(path); err != nil {
+		return 0, err
+	}
+	evictedKeysBefore := cache.Stats().EvictedKeys
+	cache.populateFromEntries(temporaryCache.entries)
+	return int(cache.Stats().EvictedKeys - evictedKeysBefore), nil
+}
+
+// populateFromEntries routes every entry in entries to the shard it hashes to, preserving the TTL it
+// had when it was saved.
+//
+// Entries are fed into their shard from oldest to newest, the same order Cache.ReadFromReader relinks
+// its list in, so that if a shard ends up with more entries than its share of maxSize, the genuinely
+// oldest entries are the ones evicted rather than whichever entries the map happened to iterate first.
+func (cache *ShardedCache) populateFromEntries(entries map[string]*Entry) {
+	sortedEntries := make([]*Entry, 0, len(entries))
+	for _, entry := range entries {
+		sortedEntries = append(sortedEntries, entry)
+	}
+	sort.Slice(sortedEntries, func(i, j int) bool {
+		return sortedEntries[i].RelevantTimestamp.Before(sortedEntries[j].RelevantTimestamp)
+	})
+	for _, entry := range sortedEntries {
+		shard := cache.shardFor(entry.Key)
+		if entry.Expiration == NoExpiration {
+			shard.Set(entry.Key, entry.Value)
+			continue
+		}
+		ttl := time.Until(time.Unix(0, entry.Expiration))
+		if ttl <= 0 {
+			continue
+		}
+		shard.SetWithTTL(entry.Key, entry.Value, ttl)
+	}
+}
@@ -0,0 +1,164 @@
+package gocache
+
+import (
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestShardedCache_WithMaxSizeDividesEvenlyAcrossShards(t *testing.T) {
+	cache := NewShardedCache(4).WithMaxSize(40)
+	for _, shard := range cache.shards {
+		if shard.MaxSize() != 10 {
+			t.Errorf("expected each shard to have a MaxSize of 10, but got %d", shard.MaxSize())
+		}
+	}
+}
+
+func TestShardedCache_WithMaxSizeFloorsToOnePerShard(t *testing.T) {
+	// 3 is smaller than the shard count, so naive integer division would floor to 0 per shard, which
+	// is indistinguishable from NoMaxSize (unlimited) -- the opposite of what the caller asked for.
+	cache := NewShardedCache(4).WithMaxSize(3)
+	for _, shard := range cache.shards {
+		if shard.MaxSize() != 1 {
+			t.Errorf("expected each shard to have a MaxSize of 1, but got %d", shard.MaxSize())
+		}
+	}
+}
+
+func TestShardedCache_WithMaxMemoryUsageDividesEvenlyAcrossShards(t *testing.T) {
+	cache := NewShardedCache(4).WithMaxMemoryUsage(4 * Kilobyte)
+	for _, shard := range cache.shards {
+		if shard.MaxMemoryUsage() != Kilobyte {
+			t.Errorf("expected each shard to have a MaxMemoryUsage of %d, but got %d", Kilobyte, shard.MaxMemoryUsage())
+		}
+	}
+}
+
+func TestShardedCache_WithMaxMemoryUsageFloorsToOnePerShard(t *testing.T) {
+	// 3 is smaller than the shard count, so naive integer division would floor to 0 per shard, which
+	// is indistinguishable from NoMaxMemoryUsage (unlimited) -- the opposite of what the caller asked for.
+	cache := NewShardedCache(4).WithMaxMemoryUsage(3)
+	for _, shard := range cache.shards {
+		if shard.MaxMemoryUsage() != 1 {
+			t.Errorf("expected each shard to have a MaxMemoryUsage of 1, but got %d", shard.MaxMemoryUsage())
+		}
+	}
+}
+
+func TestShardedCache_SetGetDelete(t *testing.T) {
+	cache := NewShardedCache(4)
+	cache.Set("key1", "value1")
+	cache.SetWithTTL("key2", "value2", time.Minute)
+	if value, ok := cache.Get("key1"); !ok || value != "value1" {
+		t.Errorf("expected key1 to have value 'value1', but got '%v' (ok=%v)", value, ok)
+	}
+	if value, ok := cache.Get("key2"); !ok || value != "value2" {
+		t.Errorf("expected key2 to have value 'value2', but got '%v' (ok=%v)", value, ok)
+	}
+	if _, ok := cache.Get("missing"); ok {
+		t.Error("expected missing to not be in the cache")
+	}
+	if !cache.Delete("key1") {
+		t.Error("expected key1 to have been deleted")
+	}
+	if _, ok := cache.Get("key1"); ok {
+		t.Error("expected key1 to no longer be in the cache after Delete")
+	}
+	if cache.Delete("key1") {
+		t.Error("expected deleting an already-deleted key to return false")
+	}
+}
+
+func TestShardedCache_Count(t *testing.T) {
+	cache := NewShardedCache(4)
+	for n := 0; n < 20; n++ {
+		cache.Set(strconv.Itoa(n), n)
+	}
+	if cache.Count() != 20 {
+		t.Errorf("expected Count() to be 20, but got %d", cache.Count())
+	}
+	cache.Clear()
+	if cache.Count() != 0 {
+		t.Errorf("expected Count() to be 0 after Clear, but got %d", cache.Count())
+	}
+}
+
+func TestShardedCache_StatsAggregatesAcrossShards(t *testing.T) {
+	cache := NewShardedCache(4).WithMaxSize(4) // 1 entry per shard, so most inserts will evict
+	for n := 0; n < 20; n++ {
+		cache.Set(strconv.Itoa(n), n)
+	}
+	for n := 0; n < 20; n++ {
+		cache.Get(strconv.Itoa(n))
+	}
+	cache.Get("definitely-not-a-key")
+	var wantHits, wantMisses, wantEvictions uint64
+	for _, shard := range cache.shards {
+		shardStats := shard.Stats()
+		wantHits += shardStats.Hits
+		wantMisses += shardStats.Misses
+		wantEvictions += shardStats.EvictedKeys
+	}
+	stats := cache.Stats()
+	if stats.Hits != wantHits || stats.Misses != wantMisses || stats.EvictedKeys != wantEvictions {
+		t.Errorf("expected Stats() to be the sum of every shard's stats (%d/%d/%d), but got %d/%d/%d",
+			wantHits, wantMisses, wantEvictions, stats.Hits, stats.Misses, stats.EvictedKeys)
+	}
+	if stats.Misses == 0 {
+		t.Error("expected at least one miss from the lookup of a key that was never set")
+	}
+	if int(stats.EvictedKeys) != 20-cache.Count() {
+		t.Errorf("expected EvictedKeys to account for every inserted key no longer present (%d), but got %d", 20-cache.Count(), stats.EvictedKeys)
+	}
+}
+
+func TestShardedCache_ShardForRoutesKeysAcrossShards(t *testing.T) {
+	cache := NewShardedCache(8)
+	seenShards := make(map[*Cache]bool)
+	for n := 0; n < 100; n++ {
+		key := strconv.Itoa(n)
+		if cache.shardFor(key) != cache.shardFor(key) {
+			t.Fatalf("expected shardFor(%s) to consistently return the same shard", key)
+		}
+		seenShards[cache.shardFor(key)] = true
+	}
+	if len(seenShards) < 2 {
+		t.Errorf("expected keys to be spread across more than one shard via FNV-1a hashing, but they all landed on %d shard(s)", len(seenShards))
+	}
+}
+
+func TestShardedCache_ReadFromFilePreservesEvictionOrder(t *testing.T) {
+	const file = "TestShardedCache_ReadFromFilePreservesEvictionOrder.cache"
+	defer os.Remove(file)
+	cache := NewShardedCache(1)
+	for n := 0; n < 10; n++ {
+		cache.Set(strconv.Itoa(n), n)
+		// To make sure that two entries don't get the exact same timestamp, as that might mess up the order
+		time.Sleep(time.Nanosecond)
+	}
+	if err := cache.SaveToFile(file); err != nil {
+		t.Fatal("shouldn't have returned an error, but got:", err.Error())
+	}
+	newCache := NewShardedCache(1).WithMaxSize(7)
+	numberOfEntriesEvicted, err := newCache.ReadFromFile(file)
+	if err != nil {
+		t.Fatal("shouldn't have returned an error, but got:", err.Error())
+	}
+	if numberOfEntriesEvicted != 3 {
+		t.Error("expected 3 entries to have been evicted, but got", numberOfEntriesEvicted)
+	}
+	// The 3 oldest entries (0, 1 and 2) should be the ones evicted, regardless of map iteration order
+	for _, key := range []string{"0", "1", "2"} {
+		if _, ok := newCache.Get(key); ok {
+			t.Errorf("expected key %s to have been evicted, but it was still present", key)
+		}
+	}
+	for n := 3; n < 10; n++ {
+		key := strconv.Itoa(n)
+		if _, ok := newCache.Get(key); !ok {
+			t.Errorf("expected key %s to still be present, but it was evicted", key)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package gocache
+
+// Statistics contains statistics from the cache
+type Statistics struct {
+	// Hits is the number of times a key was looked up and found in the cache, including lookups that
+	// found the entry expired (and therefore deleted it and returned no value)
+	Hits uint64
+
+	// Misses is the number of times a key was looked up but had no entry in the cache
+	Misses uint64
+
+	// EvictedKeys is the number of keys that have been evicted
+	EvictedKeys uint64
+}
@@ -0,0 +1,81 @@
+package gocache
+
+import (
+	"time"
+	"unsafe"
+)
+
+// Entry is a cache entry parameterized over the key type K and the value type V
+type Entry[K comparable, V any] struct {
+	// Key is the key by which the entry can be retrieved
+	Key K
+
+	// Value is the value associated to the Key
+	Value V
+
+	// RelevantTimestamp is the timestamp used to determine whether the entry is relevant or not.
+	// This value is updated when the entry is created, and, if the eviction policy is LeastRecentlyUsed,
+	// every time the entry is accessed.
+	RelevantTimestamp time.Time
+
+	// Expiration is the unix nanosecond timestamp at which the entry expires, or NoExpiration if the
+	// entry should never expire
+	Expiration int64
+
+	previous *Entry[K, V]
+	next     *Entry[K, V]
+}
+
+// Accessed updates the RelevantTimestamp of the entry to now
+func (entry *Entry[K, V]) Accessed() {
+	entry.RelevantTimestamp = time.Now()
+}
+
+// Expired returns whether the entry has expired
+func (entry *Entry[K, V]) Expired() bool {
+	if entry.Expiration > 0 {
+		return time.Now().UnixNano() > entry.Expiration
+	}
+	return false
+}
+
+// Previous returns the entry before this one in the cache's list (towards the tail), or nil.
+// This, along with SetPrevious, Next and SetNext, exists so that entrylist.MoveToHead/RemoveReferences
+// can relink entries without needing direct access to the unexported previous/next fields.
+func (entry *Entry[K, V]) Previous() *Entry[K, V] {
+	return entry.previous
+}
+
+// SetPrevious sets the entry before this one in the cache's list
+func (entry *Entry[K, V]) SetPrevious(previous *Entry[K, V]) {
+	entry.previous = previous
+}
+
+// Next returns the entry after this one in the cache's list (towards the head), or nil
+func (entry *Entry[K, V]) Next() *Entry[K, V] {
+	return entry.next
+}
+
+// SetNext sets the entry after this one in the cache's list
+func (entry *Entry[K, V]) SetNext(next *Entry[K, V]) {
+	entry.next = next
+}
+
+// SizeInBytes returns an approximation of the size of the entry in bytes
+func (entry *Entry[K, V]) SizeInBytes() int {
+	return sizeOf(entry.Key) + sizeOf(entry.Value) + int(unsafe.Sizeof(entry.RelevantTimestamp)) + int(unsafe.Sizeof(entry.Expiration))
+}
+
+// sizeOf returns an approximation of the size of value in bytes, special-casing string and []byte so
+// that their actual byte length is counted instead of unsafe.Sizeof's fixed-size header, which would
+// otherwise report the same ~16 bytes for a one-character string as for a one-megabyte one.
+func sizeOf(value any) int {
+	switch v := value.(type) {
+	case string:
+		return len(v)
+	case []byte:
+		return len(v)
+	default:
+		return int(unsafe.Sizeof(value))
+	}
+}
@@ -0,0 +1,16 @@
+package gocache
+
+// EvictionPolicy is the eviction policy of a Cache[K, V]
+//
+// It has the FirstInFirstOut and LeastRecentlyUsed policies of the non-generic Cache; the non-generic
+// Cache's SIEVE policy is not available here.
+type EvictionPolicy int
+
+const (
+	// FirstInFirstOut means that the cache will evict the oldest entry first, regardless of whether
+	// it has been accessed recently or not
+	FirstInFirstOut EvictionPolicy = iota
+
+	// LeastRecentlyUsed means that the cache will evict the least recently used entry first
+	LeastRecentlyUsed
+)
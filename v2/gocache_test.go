@@ -0,0 +1,162 @@
+package gocache
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCache_SetAndGet(t *testing.T) {
+	cache := NewCache[string, int]()
+	cache.Set("key", 1)
+	value, ok := cache.Get("key")
+	if !ok {
+		t.Fatal("expected key to be present")
+	}
+	if value != 1 {
+		t.Errorf("expected value to be 1, but got %d", value)
+	}
+	if _, ok := cache.Get("missing"); ok {
+		t.Error("expected missing to not be present")
+	}
+}
+
+func TestCache_SetWithTTL(t *testing.T) {
+	cache := NewCache[string, string]()
+	cache.SetWithTTL("key", "value", time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if _, ok := cache.Get("key"); ok {
+		t.Error("expected key to have expired by now")
+	}
+}
+
+func TestCache_GetAll(t *testing.T) {
+	cache := NewCache[string, int]()
+	cache.Set("key1", 1)
+	cache.Set("key2", 2)
+	entries := cache.GetAll([]string{"key1", "key2", "key3"})
+	if entries["key1"] != 1 || entries["key2"] != 2 {
+		t.Errorf("expected key1 and key2 to have their values, but got %v", entries)
+	}
+	if entries["key3"] != 0 {
+		t.Errorf("expected key3 to be the zero value, but got %d", entries["key3"])
+	}
+}
+
+func TestCache_Delete(t *testing.T) {
+	cache := NewCache[string, int]()
+	cache.Set("key", 1)
+	if !cache.Delete("key") {
+		t.Fatal("expected Delete to return true")
+	}
+	if _, ok := cache.Get("key"); ok {
+		t.Error("expected key to no longer be present")
+	}
+	if cache.Delete("key") {
+		t.Error("expected Delete to return false for a key that no longer exists")
+	}
+}
+
+func TestCache_Clear(t *testing.T) {
+	cache := NewCache[string, int]()
+	cache.Set("key1", 1)
+	cache.Set("key2", 2)
+	cache.Clear()
+	if cache.Count() != 0 {
+		t.Errorf("expected Count() to be 0 after Clear, but got %d", cache.Count())
+	}
+}
+
+func TestCache_TTLAndExpire(t *testing.T) {
+	cache := NewCache[string, int]()
+	cache.Set("key", 1)
+	if _, err := cache.TTL("key"); err != ErrKeyHasNoExpiration {
+		t.Errorf("expected ErrKeyHasNoExpiration, but got %v", err)
+	}
+	if !cache.Expire("key", time.Hour) {
+		t.Fatal("expected Expire to return true")
+	}
+	ttl, err := cache.TTL("key")
+	if err != nil {
+		t.Fatal("shouldn't have returned an error, but got:", err.Error())
+	}
+	if ttl <= 0 || ttl > time.Hour {
+		t.Errorf("expected ttl to be positive and at most an hour, but got %v", ttl)
+	}
+}
+
+func TestCache_WithMaxSizeEvictsFIFO(t *testing.T) {
+	cache := NewCache[string, int]().WithMaxSize(2)
+	cache.Set("key1", 1)
+	cache.Set("key2", 2)
+	cache.Set("key3", 3)
+	if cache.Count() != 2 {
+		t.Fatalf("expected Count() to be 2, but got %d", cache.Count())
+	}
+	if _, ok := cache.Get("key1"); ok {
+		t.Error("expected key1 to have been evicted since it was the oldest")
+	}
+}
+
+func TestCache_WithEvictionPolicyLeastRecentlyUsed(t *testing.T) {
+	cache := NewCache[string, int]().WithMaxSize(2).WithEvictionPolicy(LeastRecentlyUsed)
+	cache.Set("key1", 1)
+	cache.Set("key2", 2)
+	cache.Get("key1") // key1 becomes the most recently used, so key2 should be evicted instead
+	cache.Set("key3", 3)
+	if _, ok := cache.Get("key2"); ok {
+		t.Error("expected key2 to have been evicted since it was the least recently used")
+	}
+	if _, ok := cache.Get("key1"); !ok {
+		t.Error("expected key1 to still be present")
+	}
+}
+
+func TestEntry_SizeInBytesMeasuresStringAndByteSliceLengths(t *testing.T) {
+	shortEntry := &Entry[string, string]{Key: "k", Value: "v"}
+	longEntry := &Entry[string, string]{Key: "k", Value: strings.Repeat("v", 1024)}
+	if longEntry.SizeInBytes() <= shortEntry.SizeInBytes() {
+		t.Errorf("expected a 1024-byte value to report a larger SizeInBytes than a 1-byte value, but got %d and %d", longEntry.SizeInBytes(), shortEntry.SizeInBytes())
+	}
+	if longEntry.SizeInBytes()-shortEntry.SizeInBytes() != 1023 {
+		t.Errorf("expected the difference in SizeInBytes to be exactly the difference in value length (1023), but got %d", longEntry.SizeInBytes()-shortEntry.SizeInBytes())
+	}
+	byteSliceEntry := &Entry[string, []byte]{Key: "k", Value: make([]byte, 2048)}
+	if byteSliceEntry.SizeInBytes() < 2048 {
+		t.Errorf("expected SizeInBytes to account for the full length of a []byte value, but got %d", byteSliceEntry.SizeInBytes())
+	}
+}
+
+func TestCache_WithMaxMemoryUsageEvicts(t *testing.T) {
+	cache := NewCache[string, string]()
+	entrySize := (&Entry[string, string]{Key: "key1", Value: strings.Repeat("v", 100)}).SizeInBytes()
+	cache.WithMaxMemoryUsage(entrySize * 2)
+	cache.Set("key1", strings.Repeat("v", 100))
+	cache.Set("key2", strings.Repeat("v", 100))
+	cache.Set("key3", strings.Repeat("v", 100))
+	if cache.Count() >= 3 {
+		t.Errorf("expected at least one entry to have been evicted once maxMemoryUsage was exceeded, but Count() is %d", cache.Count())
+	}
+	if _, ok := cache.Get("key1"); ok {
+		t.Error("expected key1 to have been evicted since it was the oldest")
+	}
+	if _, ok := cache.Get("key3"); !ok {
+		t.Error("expected key3 to still be present")
+	}
+}
+
+func TestCache_StartJanitorAndStopJanitor(t *testing.T) {
+	cache := NewCache[string, int]()
+	cache.SetWithTTL("key", 1, time.Nanosecond)
+	if err := cache.StartJanitor(time.Millisecond); err != nil {
+		t.Fatal("shouldn't have returned an error, but got:", err.Error())
+	}
+	defer cache.StopJanitor()
+	if err := cache.StartJanitor(time.Millisecond); err != ErrJanitorAlreadyRunning {
+		t.Errorf("expected ErrJanitorAlreadyRunning, but got %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if cache.Count() != 0 {
+		t.Errorf("expected the janitor to have deleted the expired key, but Count() is %d", cache.Count())
+	}
+}
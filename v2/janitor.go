@@ -0,0 +1,30 @@
+package gocache
+
+import "time"
+
+// StartJanitor starts a background task that periodically deletes every expired entry from the cache,
+// at the interval specified by the given duration.
+//
+// Returns ErrJanitorAlreadyRunning if the janitor is already running.
+func (cache *Cache[K, V]) StartJanitor(interval time.Duration) error {
+	if err := cache.janitorRunner.Start(interval, cache.expireEntries); err != nil {
+		return ErrJanitorAlreadyRunning
+	}
+	return nil
+}
+
+// StopJanitor stops the janitor, if one is running
+func (cache *Cache[K, V]) StopJanitor() {
+	cache.janitorRunner.Stop()
+}
+
+// expireEntries deletes every entry in the cache that has expired
+func (cache *Cache[K, V]) expireEntries() {
+	cache.mutex.Lock()
+	for key, entry := range cache.entries {
+		if entry.Expired() {
+			cache.delete(key)
+		}
+	}
+	cache.mutex.Unlock()
+}